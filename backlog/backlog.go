@@ -0,0 +1,132 @@
+// Package backlog coalesces build jobs for the same repository and branch
+// so that a burst of pushes (e.g. a force-push or a rapid series of
+// commits) only ever triggers one build.
+package backlog
+
+import (
+	"ironman/logging"
+	"time"
+)
+
+// DefaultDebounce is used when a Backlog is created without an explicit
+// debounce window.
+const DefaultDebounce = 10 * time.Second
+
+// key identifies the build that a job belongs to.
+type key struct {
+	URL    string
+	Branch string
+}
+
+type pendingJob struct {
+	job   logging.Job
+	timer *time.Timer
+}
+
+// Backlog holds incoming jobs for a debounce window before releasing them
+// on the Jobs channel. A new job for the same repository and branch
+// replaces the pending one and resets its timer, so only the most recent
+// push within the window is built.
+type Backlog struct {
+	debounce time.Duration
+	pending  map[key]*pendingJob
+
+	incoming chan logging.Job
+	fire     chan key
+	promote  chan key
+	cancel   chan key
+
+	Jobs chan logging.Job
+}
+
+// New creates a Backlog with the given debounce window and starts the
+// goroutine that manages it. A debounce of zero or less uses
+// DefaultDebounce.
+func New(debounce time.Duration) *Backlog {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	b := &Backlog{
+		debounce: debounce,
+		pending:  make(map[key]*pendingJob),
+
+		incoming: make(chan logging.Job),
+		fire:     make(chan key),
+		promote:  make(chan key),
+		cancel:   make(chan key),
+
+		Jobs: make(chan logging.Job),
+	}
+
+	go b.run()
+
+	return b
+}
+
+// Add queues a job, debouncing it against any pending job for the same
+// repository and branch.
+func (b *Backlog) Add(j logging.Job) {
+	b.incoming <- j
+}
+
+// Promote releases the pending job for a repository and branch immediately,
+// without waiting for the debounce window to elapse. It is a no-op if
+// nothing is pending for that key.
+func (b *Backlog) Promote(url, branch string) {
+	b.promote <- key{URL: url, Branch: branch}
+}
+
+// Cancel drops the pending job for a repository and branch without
+// releasing it.
+func (b *Backlog) Cancel(url, branch string) {
+	b.cancel <- key{URL: url, Branch: branch}
+}
+
+// Now releases j onto Jobs immediately, bypassing the debounce window
+// entirely. Unlike Add, it never looks at -- and can't be clobbered by, or
+// clobber -- any job already pending for the same repository and branch, so
+// it's the right call for a one-off trigger such as a manual restart rather
+// than a push that should coalesce with other pushes.
+func (b *Backlog) Now(j logging.Job) {
+	b.Jobs <- j
+}
+
+func (b *Backlog) run() {
+	for {
+		select {
+		case j := <-b.incoming:
+			k := key{URL: j.URL, Branch: j.Branch}
+
+			if p, ok := b.pending[k]; ok {
+				p.job = j
+				p.timer.Reset(b.debounce)
+				continue
+			}
+
+			b.pending[k] = &pendingJob{
+				job:   j,
+				timer: time.AfterFunc(b.debounce, func() { b.fire <- k }),
+			}
+
+		case k := <-b.promote:
+			if p, ok := b.pending[k]; ok {
+				p.timer.Stop()
+				delete(b.pending, k)
+				b.Jobs <- p.job
+			}
+
+		case k := <-b.cancel:
+			if p, ok := b.pending[k]; ok {
+				p.timer.Stop()
+				delete(b.pending, k)
+			}
+
+		case k := <-b.fire:
+			if p, ok := b.pending[k]; ok {
+				delete(b.pending, k)
+				b.Jobs <- p.job
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package backlog
+
+import (
+	"ironman/logging"
+	"testing"
+	"time"
+)
+
+func TestCoalescesRapidPushes(t *testing.T) {
+	b := New(20 * time.Millisecond)
+
+	b.Add(logging.Job{URL: "url", Branch: "master", Commit: "one"})
+	b.Add(logging.Job{URL: "url", Branch: "master", Commit: "two"})
+
+	select {
+	case j := <-b.Jobs:
+		if j.Commit != "two" {
+			t.Error("Wrong commit released: ", j.Commit)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("No job released within debounce window")
+	}
+}
+
+func TestKeepsBranchesSeparate(t *testing.T) {
+	b := New(20 * time.Millisecond)
+
+	b.Add(logging.Job{URL: "url", Branch: "master", Commit: "one"})
+	b.Add(logging.Job{URL: "url", Branch: "develop", Commit: "two"})
+
+	seen := map[string]bool{}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case j := <-b.Jobs:
+			seen[j.Branch] = true
+		case <-time.After(200 * time.Millisecond):
+			t.Error("Missing released job")
+		}
+	}
+
+	if !seen["master"] || !seen["develop"] {
+		t.Error("Did not release both branches: ", seen)
+	}
+}
+
+func TestPromoteReleasesImmediately(t *testing.T) {
+	b := New(time.Hour)
+
+	b.Add(logging.Job{URL: "url", Branch: "master", Commit: "one"})
+	b.Promote("url", "master")
+
+	select {
+	case j := <-b.Jobs:
+		if j.Commit != "one" {
+			t.Error("Wrong commit released: ", j.Commit)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("Promote did not release the pending job")
+	}
+}
+
+func TestNowDoesNotClobberPending(t *testing.T) {
+	b := New(time.Hour)
+
+	b.Add(logging.Job{URL: "url", Branch: "master", Commit: "pending"})
+	b.Now(logging.Job{URL: "url", Branch: "master", Commit: "restarted"})
+
+	select {
+	case j := <-b.Jobs:
+		if j.Commit != "restarted" {
+			t.Error("Wrong commit released: ", j.Commit)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("Now did not release its job")
+	}
+
+	b.Promote("url", "master")
+
+	select {
+	case j := <-b.Jobs:
+		if j.Commit != "pending" {
+			t.Error("Now should not have consumed the pending job: ", j.Commit)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("Pending job was lost after Now")
+	}
+}
@@ -0,0 +1,119 @@
+package callbacks
+
+import (
+	"encoding/json"
+	"ironman/logging"
+	"log"
+	"time"
+)
+
+var _ Callback = (*BitbucketCallback)(nil)
+
+// BitbucketCallback provides all structs to unmarshal a Bitbucket "repo:push"
+// webhook.
+type BitbucketCallback struct {
+	Push       BitbucketPush       `json:"push"`
+	Repository BitbucketRepository `json:"repository"`
+	Actor      BitbucketUser       `json:"actor"`
+}
+
+type BitbucketPush struct {
+	Changes []BitbucketChange `json:"changes"`
+}
+
+type BitbucketChange struct {
+	New    BitbucketBranch `json:"new"`
+	Closed bool            `json:"closed"`
+}
+
+type BitbucketBranch struct {
+	Name   string          `json:"name"`
+	Target BitbucketTarget `json:"target"`
+}
+
+type BitbucketTarget struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+}
+
+type BitbucketRepository struct {
+	FullName string         `json:"full_name"`
+	Links    BitbucketLinks `json:"links"`
+}
+
+type BitbucketLinks struct {
+	HTML BitbucketHref `json:"html"`
+}
+
+type BitbucketHref struct {
+	Href string `json:"href"`
+}
+
+type BitbucketUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Branch returns the name of the branch.
+func (b *BitbucketCallback) Branch() string {
+	if len(b.Push.Changes) == 0 {
+		return ""
+	}
+	return b.Push.Changes[len(b.Push.Changes)-1].New.Name
+}
+
+// URL returns the URL for the repository.
+func (b *BitbucketCallback) URL() string {
+	return b.Repository.Links.HTML.Href
+}
+
+// By returns who pushed / triggered the callback. Format Name <email>.
+// Bitbucket does not include an email address in push payloads.
+func (b *BitbucketCallback) By() (string, string) {
+	return b.Actor.DisplayName, ""
+}
+
+// Returns if this commit should be build. Do not build if the branch was
+// deleted for example.
+func (b *BitbucketCallback) ShouldBuild() bool {
+	if len(b.Push.Changes) == 0 {
+		return false
+	}
+	return b.Push.Changes[len(b.Push.Changes)-1].Closed == false
+}
+
+// Returns the ID of the head commit.
+func (b *BitbucketCallback) Commit() string {
+	if len(b.Push.Changes) == 0 {
+		return ""
+	}
+	return b.Push.Changes[len(b.Push.Changes)-1].New.Target.Hash
+}
+
+// Parse a Bitbucket request body and add it to the build queue.
+func parseBitbucket(jobs chan logging.Job, body []byte) {
+	var cb BitbucketCallback
+	err := json.Unmarshal(body, &cb)
+
+	name, email := cb.By()
+
+	j := logging.Job{
+		URL:       cb.URL(),
+		Branch:    cb.Branch(),
+		Timestamp: time.Now(),
+		Commit:    cb.Commit(),
+		Name:      name,
+		Email:     email,
+	}
+
+	if err != nil {
+		log.Println(string(body))
+		panic("Could not unmarshal request")
+	}
+
+	if cb.ShouldBuild() == true {
+		jobs <- j
+	} else {
+		log.Println("Not adding", cb.URL(), cb.Branch(), "to build queue")
+	}
+}
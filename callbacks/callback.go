@@ -0,0 +1,18 @@
+package callbacks
+
+// Callback is implemented by every webhook payload this package knows how
+// to parse. It normalises the provider-specific fields needed to enqueue a
+// build.
+type Callback interface {
+	// Branch returns the name of the branch that was pushed to.
+	Branch() string
+	// URL returns the URL of the repository.
+	URL() string
+	// By returns who pushed / triggered the callback. Format Name, email.
+	By() (string, string)
+	// Commit returns the ID of the head commit.
+	Commit() string
+	// ShouldBuild returns if this commit should be built. Do not build if
+	// the branch was deleted for example.
+	ShouldBuild() bool
+}
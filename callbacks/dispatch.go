@@ -0,0 +1,52 @@
+package callbacks
+
+import (
+	"ironman/logging"
+	"log"
+	"net/http"
+
+	"github.com/fallenhitokiri/leeroyci/backlog"
+)
+
+// Provider identifies which git hosting service sent a webhook.
+type Provider string
+
+// Define the webhook providers Dispatch knows how to route.
+const (
+	ProviderGitHub    Provider = "github"
+	ProviderBitbucket Provider = "bitbucket"
+	ProviderGitea     Provider = "gitea"
+)
+
+// Dispatch inspects the headers of an incoming webhook request, routes the
+// body to the parser for the provider that sent it, and returns which
+// provider that was so the caller can record it against the repository.
+// It returns the empty Provider if no known provider header is present.
+// Any job the parser produces goes through bl rather than straight to the
+// runner, so a burst of pushes to the same branch gets coalesced into one
+// build.
+func Dispatch(bl *backlog.Backlog, header http.Header, body []byte) Provider {
+	relay := make(chan logging.Job, 1)
+	defer close(relay)
+
+	go func() {
+		for j := range relay {
+			bl.Add(j)
+		}
+	}()
+
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		parseGitea(relay, body)
+		return ProviderGitea
+	case header.Get("X-Event-Key") != "":
+		parseBitbucket(relay, body)
+		return ProviderBitbucket
+	case header.Get("X-GitHub-Event") != "":
+		parseGitHub(relay, body)
+		return ProviderGitHub
+	default:
+		log.Println("Unrecognised webhook provider, headers:", header)
+		return ""
+	}
+}
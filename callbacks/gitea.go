@@ -0,0 +1,101 @@
+package callbacks
+
+import (
+	"encoding/json"
+	"ironman/logging"
+	"log"
+	"strings"
+	"time"
+)
+
+var _ Callback = (*GiteaCallback)(nil)
+
+// GiteaCallback provides all structs to unmarshal a Gitea push webhook.
+// Gitea mirrors the shape of GitHub's push event payload.
+type GiteaCallback struct {
+	Ref        string
+	Before     string
+	After      string
+	CompareURL string `json:"compare_url"`
+	Commits    []GiteaCommit
+	HeadCommit GiteaCommit `json:"head_commit"`
+	Repository GiteaRepository
+	Pusher     GiteaUser
+}
+
+type GiteaCommit struct {
+	Id        string
+	Message   string
+	Url       string
+	Timestamp string
+	Author    GiteaUser
+	Committer GiteaUser
+}
+
+type GiteaRepository struct {
+	Id       int64
+	FullName string `json:"full_name"`
+	HtmlUrl  string `json:"html_url"`
+	Private  bool
+}
+
+type GiteaUser struct {
+	Name     string
+	Email    string
+	Username string
+}
+
+// Branch returns the name of the branch.
+func (g *GiteaCallback) Branch() string {
+	s := strings.Split(g.Ref, "/")
+	return s[len(s)-1]
+}
+
+// URL returns the URL for the repository.
+func (g *GiteaCallback) URL() string {
+	return g.Repository.HtmlUrl
+}
+
+// By returns who pushed / triggered the callback. Format Name <email>.
+func (g *GiteaCallback) By() (string, string) {
+	return g.Pusher.Name, g.Pusher.Email
+}
+
+// Returns if this commit should be build. Gitea sets After to the all-zero
+// hash when a branch is deleted.
+func (g *GiteaCallback) ShouldBuild() bool {
+	return g.After != "0000000000000000000000000000000000000000"
+}
+
+// Returns the ID of the head commit.
+func (g *GiteaCallback) Commit() string {
+	return g.HeadCommit.Id
+}
+
+// Parse a Gitea request body and add it to the build queue.
+func parseGitea(jobs chan logging.Job, body []byte) {
+	var cb GiteaCallback
+	err := json.Unmarshal(body, &cb)
+
+	name, email := cb.By()
+
+	j := logging.Job{
+		URL:       cb.URL(),
+		Branch:    cb.Branch(),
+		Timestamp: time.Now(),
+		Commit:    cb.Commit(),
+		Name:      name,
+		Email:     email,
+	}
+
+	if err != nil {
+		log.Println(string(body))
+		panic("Could not unmarshal request")
+	}
+
+	if cb.ShouldBuild() == true {
+		jobs <- j
+	} else {
+		log.Println("Not adding", cb.URL(), cb.Branch(), "to build queue")
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 )
 
+var _ Callback = (*GitHubCallback)(nil)
+
 type GitHubCallback struct {
 	Ref         string
 	After       string
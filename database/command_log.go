@@ -0,0 +1,22 @@
+package database
+
+// CommandLogHook, when set, is called whenever a line of command output is
+// recorded for a job, so anything tailing the job live (see pubsub.Hub)
+// sees it as it's produced. database cannot depend on a package that
+// imports it, so the subscriber registers itself here instead.
+var CommandLogHook func(jobID int64, line string)
+
+// AppendCommandLog is the write path command runners use to record a line
+// of output for a job as it executes.
+func AppendCommandLog(jobID int64, line string) {
+	cl := &CommandLog{
+		JobID:  jobID,
+		Output: line,
+	}
+
+	db.Save(cl)
+
+	if CommandLogHook != nil {
+		CommandLogHook(jobID, line)
+	}
+}
@@ -13,6 +13,32 @@ const (
 	JobStatusPending = "pending"
 )
 
+// Define the points in a job's lifecycle RegisterJobLifecycleHook's
+// callbacks are invoked for.
+const (
+	JobPhaseStarted    = "started"
+	JobPhaseTasksDone  = "tasks_done"
+	JobPhaseDeployDone = "deploy_done"
+)
+
+// jobLifecycleHooks are called whenever a job reaches a point worth
+// reporting to an external system, such as GitHub's commit status API or a
+// chat notification. database cannot import those integrations directly
+// without an import cycle, so they register themselves here instead.
+var jobLifecycleHooks []func(job *Job, phase string)
+
+// RegisterJobLifecycleHook adds fn to the callbacks invoked every time a
+// job is started, finishes its tasks, or finishes its deploy.
+func RegisterJobLifecycleHook(fn func(job *Job, phase string)) {
+	jobLifecycleHooks = append(jobLifecycleHooks, fn)
+}
+
+func notifyStatusHook(j *Job, phase string) {
+	for _, fn := range jobLifecycleHooks {
+		fn(j, phase)
+	}
+}
+
 // Job stores all information about one commit and the executed tasks.
 type Job struct {
 	ID int64
@@ -125,16 +151,18 @@ func (j *Job) Status() string {
 	return JobStatusPending
 }
 
-// TasksDone sets TasksDone
+// TasksDone sets TasksFinished to now and notifies the lifecycle hooks.
 func (j *Job) TasksDone() {
 	j.TasksFinished = time.Now()
 	db.Save(j)
+	notifyStatusHook(j, JobPhaseTasksDone)
 }
 
-// DeployDone sets DeployDone
+// DeployDone sets DeployFinished to now and notifies the lifecycle hooks.
 func (j *Job) DeployDone() {
 	j.DeployFinished = time.Now()
 	db.Save(j)
+	notifyStatusHook(j, JobPhaseDeployDone)
 }
 
 // URL returns the URL for this job, including the configured server URL.
@@ -170,6 +198,7 @@ func (j *Job) ShouldDeploy() bool {
 func (j *Job) Started() {
 	j.TasksStarted = time.Now()
 	db.Save(j)
+	notifyStatusHook(j, JobPhaseStarted)
 }
 
 // IsRunning returns true if this job is not finished with all its
@@ -197,6 +226,21 @@ func (j *Job) Cancel() {
 	db.Save(j)
 }
 
+// JobForRestart looks up an existing job so its repository, branch and
+// commit details can be rebuilt without waiting for a new push. It does
+// not create a new Job row itself -- the caller enqueues a fresh
+// logging.Job built from the returned Job's fields, and the runner
+// persists it exactly as it would for a new push.
+func JobForRestart(id int64) (*Job, error) {
+	orig := GetJob(id)
+
+	if orig.ID == 0 {
+		return nil, fmt.Errorf("database: no job with id %d", id)
+	}
+
+	return orig, nil
+}
+
 // SearchJobs returns all jobs where the branch or commit contains the query
 // string.
 func SearchJobs(query string) []*Job {
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"time"
+)
+
+// Define all notifier kinds a repository can be configured with.
+const (
+	NotifierKindHipChat = "hipchat"
+	NotifierKindSlack   = "slack"
+	NotifierKindDiscord = "discord"
+	NotifierKindTeams   = "teams"
+	NotifierKindWebhook = "webhook"
+)
+
+// Notifier stores the configuration for one notification backend attached
+// to a repository.
+type Notifier struct {
+	ID int64
+
+	RepositoryID int64
+
+	Kind     string
+	Endpoint string
+	Token    string
+	Room     string
+	Template string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CreateNotifier adds a new notifier configuration for a repository.
+func CreateNotifier(repo *Repository, kind, endpoint, token, room, tmpl string) *Notifier {
+	n := &Notifier{
+		RepositoryID: repo.ID,
+		Kind:         kind,
+		Endpoint:     endpoint,
+		Token:        token,
+		Room:         room,
+		Template:     tmpl,
+	}
+
+	db.Save(n)
+
+	return n
+}
+
+// GetNotifier returns a notifier for a given ID.
+func GetNotifier(id int64) *Notifier {
+	n := &Notifier{}
+	db.Where("ID = ?", id).Last(&n)
+	return n
+}
+
+// GetNotifiersForRepository returns all notifiers configured for a
+// repository.
+func GetNotifiersForRepository(repositoryID int64) []*Notifier {
+	var notifiers []*Notifier
+
+	db.Where("repository_id = ?", repositoryID).Find(&notifiers)
+
+	return notifiers
+}
+
+// Update updates an existing notifier configuration.
+func (n *Notifier) Update(kind, endpoint, token, room, tmpl string) (*Notifier, error) {
+	n.Kind = kind
+	n.Endpoint = endpoint
+	n.Token = token
+	n.Room = room
+	n.Template = tmpl
+
+	err := db.Save(n).Error
+
+	return n, err
+}
+
+// Delete removes a notifier configuration.
+func (n *Notifier) Delete() {
+	db.Delete(n)
+}
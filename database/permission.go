@@ -0,0 +1,86 @@
+package database
+
+import "time"
+
+// Define all roles a user can be granted on a repository, ordered from
+// least to most privileged.
+const (
+	RoleRead  = "read"
+	RoleWrite = "write"
+	RoleAdmin = "admin"
+)
+
+var roleRank = map[string]int{
+	RoleRead:  1,
+	RoleWrite: 2,
+	RoleAdmin: 3,
+}
+
+// Permission grants a user a role on a repository.
+type Permission struct {
+	ID int64
+
+	UserID       int64
+	RepositoryID int64
+	Role         string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CreatePermission grants userID the role on repositoryID, replacing any
+// permission already granted for that pair.
+func CreatePermission(userID, repositoryID int64, role string) *Permission {
+	p := GetPermission(userID, repositoryID)
+
+	if p.ID != 0 {
+		p.Role = role
+		db.Save(p)
+		return p
+	}
+
+	p = &Permission{
+		UserID:       userID,
+		RepositoryID: repositoryID,
+		Role:         role,
+	}
+
+	db.Save(p)
+
+	return p
+}
+
+// GetPermission returns the permission userID has on repositoryID, or a
+// zero-value Permission if none has been granted.
+func GetPermission(userID, repositoryID int64) *Permission {
+	p := &Permission{}
+	db.Where("user_id = ? AND repository_id = ?", userID, repositoryID).Last(&p)
+	return p
+}
+
+// GetPermissionsForUser returns every permission granted to a user across
+// all repositories.
+func GetPermissionsForUser(userID int64) []*Permission {
+	var permissions []*Permission
+	db.Where("user_id = ?", userID).Find(&permissions)
+	return permissions
+}
+
+// GetPermissionsForRepository returns every permission granted on a
+// repository.
+func GetPermissionsForRepository(repositoryID int64) []*Permission {
+	var permissions []*Permission
+	db.Where("repository_id = ?", repositoryID).Find(&permissions)
+	return permissions
+}
+
+// Delete revokes a permission.
+func (p *Permission) Delete() {
+	db.Delete(p)
+}
+
+// Allows returns true if this permission's role grants at least the access
+// level of role.
+func (p *Permission) Allows(role string) bool {
+	return roleRank[p.Role] >= roleRank[role]
+}
@@ -0,0 +1,28 @@
+package database
+
+// Define the webhook providers a repository can be configured for, so PR
+// commenting and commit statuses use the right access token and API base
+// URL. The Repository model itself lives outside this checkout, so these
+// read/write against its "provider" column directly rather than through a
+// Go struct field.
+const (
+	ProviderGitHub    = "github"
+	ProviderBitbucket = "bitbucket"
+	ProviderGitea     = "gitea"
+)
+
+// SetRepositoryProvider stores which webhook provider a repository's
+// pushes come from.
+func SetRepositoryProvider(repositoryID int64, provider string) {
+	db.Table("repositories").Where("id = ?", repositoryID).Update("provider", provider)
+}
+
+// GetRepositoryProvider returns the webhook provider stored for a
+// repository, or the empty string if none has been recorded yet.
+func GetRepositoryProvider(repositoryID int64) string {
+	var provider string
+
+	db.Table("repositories").Where("id = ?", repositoryID).Select("provider").Row().Scan(&provider)
+
+	return provider
+}
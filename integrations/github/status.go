@@ -0,0 +1,102 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"leeroy/database"
+	"log"
+	"strings"
+)
+
+// Context strings used for the two kinds of checks Leeroy reports via the
+// Statuses API.
+const (
+	StatusContextBuild  = "leeroy/build"
+	StatusContextDeploy = "leeroy/deploy"
+)
+
+// Everything needed to set a commit status via GitHub's Statuses API.
+type status struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// githubState maps a job's current status to the state GitHub's Statuses
+// API expects. A job whose commands ran and failed is reported as
+// "failure"; "error" is reserved for PostStatus being unable to report the
+// status at all, which it logs rather than posts.
+func githubState(job *database.Job) string {
+	switch job.Status() {
+	case database.JobStatusSuccess:
+		return "success"
+	case database.JobStatusError:
+		return "failure"
+	default:
+		return "pending"
+	}
+}
+
+// ownerRepo extracts "owner/repo" from a GitHub repository URL.
+func ownerRepo(url string) string {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	parts := strings.Split(trimmed, "/")
+
+	if len(parts) < 2 {
+		return trimmed
+	}
+
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+
+func init() {
+	database.RegisterJobLifecycleHook(reportJobStatus)
+}
+
+// reportJobStatus is registered via database.RegisterJobLifecycleHook so
+// Job.Started(), Job.TasksDone() and Job.DeployDone() post a commit status
+// as they run, without database needing to import this package. Bitbucket
+// and Gitea repositories are routed through here too since the hook is
+// global, so it bails out for anything that isn't a GitHub repository
+// rather than posting a GitHub Statuses request for them.
+func reportJobStatus(job *database.Job, phase string) {
+	if database.GetRepositoryProvider(job.RepositoryID) != database.ProviderGitHub {
+		return
+	}
+
+	switch phase {
+	case database.JobPhaseStarted, database.JobPhaseTasksDone:
+		PostStatus(job, job.Commit, StatusContextBuild)
+	case database.JobPhaseDeployDone:
+		PostStatus(job, job.Commit, StatusContextDeploy)
+	}
+}
+
+// PostStatus posts a commit status for sha under statusContext, derived
+// from the job's current state.
+func PostStatus(job *database.Job, sha, statusContext string) {
+	rp := database.RepositoryForURL(job.Repository.Url)
+
+	s := status{
+		State:       githubState(job),
+		TargetURL:   job.URL(),
+		Description: "Leeroy CI",
+		Context:     statusContext,
+	}
+
+	m, err := json.Marshal(&s)
+
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/statuses/%s", ownerRepo(job.Repository.Url), sha)
+
+	_, err = githubRequest("POST", path, rp.AccessKey, m)
+
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,42 @@
+// Implement Discord notifications.
+package notification
+
+import "context"
+
+const (
+	discordColorGood = 0x2ECC71
+	discordColorBad  = 0xE74C3C
+)
+
+var _ Notifier = (*Discord)(nil)
+
+// Discord delivers notifications to a Discord incoming webhook.
+type Discord struct {
+	WebhookURL string
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+}
+
+// Notify posts a notification to the configured Discord webhook.
+func (d *Discord) Notify(ctx context.Context, n *notification) error {
+	color := discordColorBad
+
+	if n.Status == true {
+		color = discordColorGood
+	}
+
+	p := discordPayload{
+		Embeds: []discordEmbed{
+			{Description: n.rendered, Color: color},
+		},
+	}
+
+	return postJSON(ctx, d.WebhookURL, p)
+}
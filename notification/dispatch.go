@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fallenhitokiri/leeroyci/database"
+)
+
+func init() {
+	database.RegisterJobLifecycleHook(reportBuildNotification)
+}
+
+// NotifierFor builds the Notifier implementation configured by a stored
+// database.Notifier.
+func NotifierFor(cfg *database.Notifier) (Notifier, error) {
+	switch cfg.Kind {
+	case database.NotifierKindHipChat:
+		return &HipChat{Token: cfg.Token, Room: cfg.Room}, nil
+	case database.NotifierKindSlack:
+		return &Slack{WebhookURL: cfg.Endpoint}, nil
+	case database.NotifierKindDiscord:
+		return &Discord{WebhookURL: cfg.Endpoint}, nil
+	case database.NotifierKindTeams:
+		return &Teams{WebhookURL: cfg.Endpoint}, nil
+	case database.NotifierKindWebhook:
+		return &Webhook{URL: cfg.Endpoint, Template: cfg.Template}, nil
+	default:
+		return nil, fmt.Errorf("notification: unknown notifier kind %q", cfg.Kind)
+	}
+}
+
+// NotifyRepository builds and enqueues a delivery for every notifier
+// configured on a repository.
+func NotifyRepository(repositoryID int64, n *notification) {
+	for _, cfg := range database.GetNotifiersForRepository(repositoryID) {
+		notifier, err := NotifierFor(cfg)
+
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		Enqueue(Delivery{Notifier: notifier, Notification: n})
+	}
+}
+
+// reportBuildNotification is registered via
+// database.RegisterJobLifecycleHook so a repository's notifiers fire once
+// the build (and, separately, the deploy) finish.
+func reportBuildNotification(job *database.Job, phase string) {
+	if phase != database.JobPhaseTasksDone && phase != database.JobPhaseDeployDone {
+		return
+	}
+
+	summary := fmt.Sprintf("%s %s: %s", job.Repository.Url, job.Branch, job.Status())
+
+	n := &notification{
+		rendered: summary,
+		Message:  summary,
+		Status:   job.Status() == database.JobStatusSuccess,
+	}
+
+	NotifyRepository(job.RepositoryID, n)
+}
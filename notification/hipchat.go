@@ -2,14 +2,20 @@
 package notification
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"net/url"
 )
 
-var api = "https://www.hipchat.com/v1/rooms/message?auth_token=%s"
+var hipchatAPI = "https://www.hipchat.com/v1/rooms/message?auth_token=%s"
+
+var _ Notifier = (*HipChat)(nil)
+
+// HipChat delivers notifications to a HipChat room.
+type HipChat struct {
+	Token string
+	Room  string
+}
 
 type hipchatPayload struct {
 	Room    string
@@ -44,23 +50,16 @@ func (h *hipchatPayload) toURLEncoded() []byte {
 	return []byte(d.Encode())
 }
 
-func hipchat(n *notification, key string, chl string) {
-	e := fmt.Sprintf(api, key)
-	p := notToHipChapt(n, chl)
+// Notify posts a notification to the configured HipChat room.
+func (h *HipChat) Notify(ctx context.Context, n *notification) error {
+	e := fmt.Sprintf(hipchatAPI, h.Token)
+	p := notToHipChat(n, h.Room)
 
-	_, err := http.Post(
-		e,
-		"application/x-www-form-urlencoded",
-		bytes.NewReader(p.toURLEncoded()),
-	)
-
-	if err != nil {
-		log.Println(err)
-	}
+	return post(ctx, e, "application/x-www-form-urlencoded", p.toURLEncoded())
 }
 
 // Convert a notification to a hipchat payload.
-func notToHipChapt(n *notification, channel string) hipchatPayload {
+func notToHipChat(n *notification, channel string) hipchatPayload {
 	p := hipchatPayload{
 		Color:   "green",
 		Notify:  true,
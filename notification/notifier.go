@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Notifier delivers a build notification to an external system.
+type Notifier interface {
+	Notify(ctx context.Context, n *notification) error
+}
+
+// notification is the payload handed to every configured Notifier for a
+// build. rendered is the human-readable summary the built-in chat backends
+// (HipChat, Slack, Discord, Teams) send as-is; Message carries the same
+// text for the generic Webhook backend's user-supplied template, since
+// text/template can't reach the unexported rendered field. Status is true
+// for a successful build.
+type notification struct {
+	rendered string
+	Message  string
+	Status   bool
+}
+
+const (
+	workers    = 4
+	maxRetries = 3
+)
+
+// Delivery pairs a notification with the notifier that should deliver it.
+type Delivery struct {
+	Notifier     Notifier
+	Notification *notification
+}
+
+// queue feeds the worker pool started by Dispatch. Enqueue is the only way
+// callers outside this package add to it.
+var queue = make(chan Delivery, 64)
+
+// Dispatch starts a small worker pool that delivers queued deliveries.
+// Running delivery through a pool with retries means a dead chat endpoint
+// can't block the build pipeline.
+func Dispatch() {
+	for i := 0; i < workers; i++ {
+		go worker(queue)
+	}
+}
+
+// Enqueue queues a delivery for the worker pool started by Dispatch.
+func Enqueue(d Delivery) {
+	queue <- d
+}
+
+func worker(q <-chan Delivery) {
+	for d := range q {
+		send(d.Notifier, d.Notification)
+	}
+}
+
+// send delivers a notification, retrying with exponential backoff on
+// failure before giving up.
+func send(n Notifier, notif *notification) {
+	backoff := time.Second
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err := n.Notify(context.Background(), notif)
+
+		if err == nil {
+			return
+		}
+
+		log.Println("Notification attempt", attempt, "failed:", err)
+
+		if attempt < maxRetries {
+			time.Sleep(backoff)
+			backoff = backoff * 2
+		}
+	}
+
+	log.Println("Giving up on notification after", maxRetries, "attempts")
+}
@@ -0,0 +1,45 @@
+// Implement Slack notifications.
+package notification
+
+import "context"
+
+var _ Notifier = (*Slack)(nil)
+
+// Slack delivers notifications to a Slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color string `json:"color"`
+	Text  string `json:"text"`
+}
+
+// Notify posts a notification to the configured Slack webhook.
+func (s *Slack) Notify(ctx context.Context, n *notification) error {
+	p := slackPayload{
+		Text: n.rendered,
+		Attachments: []slackAttachment{
+			{
+				Color: slackColor(n),
+				Text:  n.rendered,
+			},
+		},
+	}
+
+	return postJSON(ctx, s.WebhookURL, p)
+}
+
+// slackColor maps a notification's status to Slack's attachment color
+// convention.
+func slackColor(n *notification) string {
+	if n.Status == true {
+		return "good"
+	}
+	return "danger"
+}
@@ -0,0 +1,42 @@
+// Implement Microsoft Teams notifications.
+package notification
+
+import "context"
+
+const (
+	teamsColorGood = "2cbe4e"
+	teamsColorBad  = "d73a49"
+)
+
+var _ Notifier = (*Teams)(nil)
+
+// Teams delivers notifications to a Microsoft Teams incoming webhook using
+// the MessageCard format.
+type Teams struct {
+	WebhookURL string
+}
+
+type teamsPayload struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	ThemeColor string `json:"themeColor"`
+	Text       string `json:"text"`
+}
+
+// Notify posts a notification to the configured Teams webhook.
+func (t *Teams) Notify(ctx context.Context, n *notification) error {
+	color := teamsColorBad
+
+	if n.Status == true {
+		color = teamsColorGood
+	}
+
+	p := teamsPayload{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		ThemeColor: color,
+		Text:       n.rendered,
+	}
+
+	return postJSON(ctx, t.WebhookURL, p)
+}
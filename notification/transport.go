@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// postJSON marshals v and POSTs it to endpoint, returning an error on
+// transport failure or a non-2xx response.
+func postJSON(ctx context.Context, endpoint string, v interface{}) error {
+	body, err := json.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, endpoint, "application/json", body)
+}
+
+// post sends body to endpoint with the given content type, returning an
+// error on transport failure or a non-2xx response.
+func post(ctx context.Context, endpoint, contentType string, body []byte) error {
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification: unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Implement generic webhook notifications with a user-supplied body
+// template.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+)
+
+var _ Notifier = (*Webhook)(nil)
+
+// Webhook delivers notifications to an arbitrary HTTP endpoint, rendering
+// the request body from a user-supplied template.
+type Webhook struct {
+	URL         string
+	ContentType string
+	Template    string
+}
+
+// Notify renders Template against n and POSTs the result to URL. Template
+// can reference n.Message (the build summary) and n.Status (true on a
+// successful build) -- text/template can only reach exported fields, so
+// those are the two available.
+func (w *Webhook) Notify(ctx context.Context, n *notification) error {
+	tmpl, err := template.New("webhook").Parse(w.Template)
+
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+
+	if err := tmpl.Execute(&body, n); err != nil {
+		return err
+	}
+
+	contentType := w.ContentType
+
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	return post(ctx, w.URL, contentType, body.Bytes())
+}
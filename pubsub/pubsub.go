@@ -0,0 +1,74 @@
+// Package pubsub lets command runners publish output lines as they are
+// produced, so status pages can show a running build live instead of only
+// after it finishes.
+package pubsub
+
+import "sync"
+
+// Line is one line of command output published for a job.
+type Line struct {
+	JobID int64
+	Text  string
+}
+
+type subscriber chan Line
+
+// Hub fans published lines out to every active subscriber for a job.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[subscriber]bool
+	published   map[int64]int
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int64]map[subscriber]bool),
+		published:   make(map[int64]int),
+	}
+}
+
+// Publish sends a log line to every subscriber currently watching jobID. A
+// subscriber that isn't keeping up has the line dropped rather than
+// blocking the runner.
+func (h *Hub) Publish(jobID int64, text string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.published[jobID]++
+
+	for sub := range h.subscribers[jobID] {
+		select {
+		case sub <- Line{JobID: jobID, Text: text}:
+		default:
+		}
+	}
+}
+
+// Subscribe registers for lines published for jobID from this point on,
+// and returns how many lines were published for jobID before the call.
+// Callers should replay that many lines from storage and rely on the
+// returned channel for everything after, rather than re-querying storage
+// for "everything so far" -- since storage could gain rows between a
+// snapshot read and subscribing, replaying more than this count risks
+// showing lines this subscription will also deliver live. Call the
+// returned function once the subscriber is done to release its resources.
+func (h *Hub) Subscribe(jobID int64) (<-chan Line, func(), int) {
+	sub := make(subscriber, 64)
+
+	h.mu.Lock()
+	if h.subscribers[jobID] == nil {
+		h.subscribers[jobID] = make(map[subscriber]bool)
+	}
+	h.subscribers[jobID][sub] = true
+	seq := h.published[jobID]
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		delete(h.subscribers[jobID], sub)
+		h.mu.Unlock()
+	}
+
+	return sub, unsubscribe, seq
+}
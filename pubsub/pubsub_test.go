@@ -0,0 +1,53 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeReceivesPublishedLine(t *testing.T) {
+	h := NewHub()
+
+	lines, unsubscribe, _ := h.Subscribe(1)
+	defer unsubscribe()
+
+	h.Publish(1, "building...")
+
+	select {
+	case l := <-lines:
+		if l.Text != "building..." {
+			t.Error("Wrong line received: ", l.Text)
+		}
+	case <-time.After(time.Second):
+		t.Error("No line received")
+	}
+}
+
+func TestSubscribersAreIsolatedPerJob(t *testing.T) {
+	h := NewHub()
+
+	lines, unsubscribe, _ := h.Subscribe(1)
+	defer unsubscribe()
+
+	h.Publish(2, "unrelated job")
+
+	select {
+	case l := <-lines:
+		t.Error("Received line for wrong job: ", l)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscribeReturnsCountPublishedBeforeIt(t *testing.T) {
+	h := NewHub()
+
+	h.Publish(1, "one")
+	h.Publish(1, "two")
+
+	_, unsubscribe, seq := h.Subscribe(1)
+	defer unsubscribe()
+
+	if seq != 2 {
+		t.Error("Wrong sequence returned: ", seq)
+	}
+}
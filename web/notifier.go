@@ -0,0 +1,141 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+
+	"github.com/fallenhitokiri/leeroyci/database"
+)
+
+// notifierForm is the form used by admins to add or edit a repository's
+// notifier configuration.
+type notifierForm struct {
+	Kind     string `schema:"kind"`
+	Endpoint string `schema:"endpoint"`
+	Token    string `schema:"token"`
+	Room     string `schema:"room"`
+	Template string `schema:"template"`
+}
+
+// add creates a new notifier for a repository.
+func (f notifierForm) add(request *http.Request, repositoryID int64) error {
+	err := request.ParseForm()
+
+	if err != nil {
+		return err
+	}
+
+	decoder := schema.NewDecoder()
+	form := new(notifierForm)
+
+	err = decoder.Decode(form, request.PostForm)
+
+	if err != nil {
+		return err
+	}
+
+	repo := database.GetRepository(repositoryID)
+
+	database.CreateNotifier(repo, form.Kind, form.Endpoint, form.Token, form.Room, form.Template)
+
+	return nil
+}
+
+// update updates an existing notifier.
+func (f notifierForm) update(request *http.Request, id int64) error {
+	err := request.ParseForm()
+
+	if err != nil {
+		return err
+	}
+
+	decoder := schema.NewDecoder()
+	form := new(notifierForm)
+
+	err = decoder.Decode(form, request.PostForm)
+
+	if err != nil {
+		return err
+	}
+
+	n := database.GetNotifier(id)
+
+	_, err = n.Update(form.Kind, form.Endpoint, form.Token, form.Room, form.Template)
+
+	return err
+}
+
+// viewAdminListNotifiers lists and adds notifiers configured for a
+// repository. Registration should wrap this with
+// RequireRole(database.RoleAdmin, RepositoryIDFromRID, ...).
+func viewAdminListNotifiers(w http.ResponseWriter, r *http.Request) {
+	template := "repo/admin/notifiers.html"
+	ctx := make(responseContext)
+
+	vars := mux.Vars(r)
+	repositoryID, err := strconv.ParseInt(vars["rid"], 10, 64)
+
+	if err != nil {
+		ctx["error"] = err.Error()
+		render(w, r, template, ctx)
+		return
+	}
+
+	if r.Method == "POST" {
+		err := notifierForm{}.add(r, repositoryID)
+
+		if err != nil {
+			ctx["error"] = err.Error()
+		} else {
+			ctx["message"] = "Notifier added."
+		}
+	}
+
+	ctx["notifiers"] = database.GetNotifiersForRepository(repositoryID)
+
+	render(w, r, template, ctx)
+}
+
+// viewAdminEditNotifier edits a single notifier.
+func viewAdminEditNotifier(w http.ResponseWriter, r *http.Request) {
+	template := "repo/admin/notifier_edit.html"
+	ctx := make(responseContext)
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+
+	if err != nil {
+		ctx["error"] = err.Error()
+		render(w, r, template, ctx)
+		return
+	}
+
+	if r.Method == "POST" {
+		err := notifierForm{}.update(r, id)
+
+		if err == nil {
+			ctx["message"] = "Update successful."
+		} else {
+			ctx["error"] = err.Error()
+		}
+	}
+
+	ctx["notifier"] = database.GetNotifier(id)
+
+	render(w, r, template, ctx)
+}
+
+// viewAdminDeleteNotifier deletes a notifier for a given id.
+func viewAdminDeleteNotifier(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+
+	if err == nil {
+		database.GetNotifier(id).Delete()
+	}
+
+	http.Redirect(w, r, "/admin/repos/"+vars["rid"]+"/notifiers", 302)
+}
@@ -0,0 +1,145 @@
+package web
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+
+	"github.com/fallenhitokiri/leeroyci/database"
+)
+
+// contextPermission is the gorilla/context key the current user's
+// permission for the repository named in the URL is stored under.
+const contextPermission = "permission"
+
+// RequireRole wraps a handler so it only runs for a user holding at least
+// role on the repository resolveRepositoryID identifies for the request.
+// Admins always pass. Use RepositoryIDFromRID for admin screens keyed by a
+// repository ID, or RepositoryIDFromHex for the status/restart routes
+// keyed by the repository's hex-encoded URL.
+func RequireRole(role string, resolveRepositoryID func(*http.Request) (int64, error), handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := context.Get(r, contextUser).(*database.User)
+
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		repositoryID, err := resolveRepositoryID(r)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		perm := database.GetPermission(user.ID, repositoryID)
+
+		if user.Admin == false && perm.Allows(role) == false {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		context.Set(r, contextPermission, perm)
+
+		handler(w, r)
+	}
+}
+
+// RepositoryIDFromRID resolves the repository named by the {rid} URL
+// variable, for admin screens that operate directly on a repository ID.
+func RepositoryIDFromRID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["rid"], 10, 64)
+}
+
+// RepositoryIDFromHex resolves the repository named by the {hex} URL
+// variable used throughout the status routes, which hex-encodes the
+// repository's URL.
+func RepositoryIDFromHex(r *http.Request) (int64, error) {
+	decoded, err := hex.DecodeString(mux.Vars(r)["hex"])
+
+	if err != nil {
+		return 0, err
+	}
+
+	repo := database.RepositoryForURL(string(decoded))
+
+	if repo.ID == 0 {
+		return 0, fmt.Errorf("no repository for %s", decoded)
+	}
+
+	return repo.ID, nil
+}
+
+// permissionForm is the form used by admins to grant a user a role on a
+// repository.
+type permissionForm struct {
+	RepositoryID int64  `schema:"repository_id"`
+	Role         string `schema:"role"`
+}
+
+// set grants userID the form's role on its repository.
+func (f permissionForm) set(request *http.Request, userID int64) error {
+	err := request.ParseForm()
+
+	if err != nil {
+		return err
+	}
+
+	decoder := schema.NewDecoder()
+	form := new(permissionForm)
+
+	err = decoder.Decode(form, request.PostForm)
+
+	if err != nil {
+		return err
+	}
+
+	switch form.Role {
+	case database.RoleRead, database.RoleWrite, database.RoleAdmin:
+	default:
+		return fmt.Errorf("unknown role %q", form.Role)
+	}
+
+	database.CreatePermission(userID, form.RepositoryID, form.Role)
+
+	return nil
+}
+
+// viewAdminUserPermissions shows and edits the per-repository permission
+// matrix for a single user.
+func viewAdminUserPermissions(w http.ResponseWriter, r *http.Request) {
+	template := "user/admin/permissions.html"
+	ctx := make(responseContext)
+
+	vars := mux.Vars(r)
+	uid := vars["uid"]
+
+	user, err := database.GetUserByID(uid)
+
+	if err != nil {
+		ctx["error"] = err.Error()
+		render(w, r, template, ctx)
+		return
+	}
+
+	if r.Method == "POST" {
+		err := permissionForm{}.set(r, user.ID)
+
+		if err != nil {
+			ctx["error"] = err.Error()
+		} else {
+			ctx["message"] = "Permission updated."
+		}
+	}
+
+	ctx["edit_user"] = user
+	ctx["permissions"] = database.GetPermissionsForUser(user.ID)
+
+	render(w, r, template, ctx)
+}
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/fallenhitokiri/leeroyci/backlog"
+	"github.com/fallenhitokiri/leeroyci/database"
+	"ironman/logging"
+)
+
+// Restart replays a finished job for the given commit: it looks up the
+// original job and releases it to the runner via bl.Now, without requiring
+// a new push. It bypasses bl's debounce window rather than going through
+// Add, so restarting an old commit can't clobber a newer push still pending
+// for the same branch.
+// Registration should wrap this with RequireRole(database.RoleWrite,
+// RepositoryIDFromHex, ...) -- the route has a {hex} variable (the
+// repository's hex-encoded URL), not {rid}.
+func Restart(w http.ResponseWriter, r *http.Request, bl *backlog.Backlog) {
+	vars := mux.Vars(r)
+	hex := vars["hex"]
+	commit := vars["commit"]
+
+	old := database.GetJobByCommit(commit)
+
+	if old.ID == 0 {
+		http.Error(w, "No job found for commit "+commit, http.StatusNotFound)
+		return
+	}
+
+	restarted, err := database.JobForRestart(old.ID)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bl.Now(logging.Job{
+		URL:       restarted.Repository.Url,
+		Branch:    restarted.Branch,
+		Commit:    restarted.Commit,
+		Timestamp: time.Now(),
+		Name:      restarted.Name,
+		Email:     restarted.Email,
+	})
+
+	http.Redirect(w, r, "/status/commit/"+hex+"/"+commit, http.StatusFound)
+}
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/fallenhitokiri/leeroyci/database"
+	"github.com/fallenhitokiri/leeroyci/pubsub"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// hub fans out live command log lines to connected status pages.
+var hub = pubsub.NewHub()
+
+func init() {
+	// database.AppendCommandLog is the write path command runners use to
+	// record output; relay every line onto hub so Stream's live tailers
+	// see it as it's produced.
+	database.CommandLogHook = hub.Publish
+}
+
+// isWebSocketUpgrade reports whether a request is a genuine WebSocket
+// handshake. Upgrader.Upgrade already writes a failure response of its own
+// when the handshake is bad, so callers must check this before invoking it
+// rather than falling back to SSE afterwards.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// Stream serves the command log for a job. A finished job is rendered once
+// from the database; a running job is streamed live over WebSocket, with a
+// Server-Sent Events fallback for clients that can't upgrade. Either way,
+// buffered lines already in the database are replayed first using the
+// offset query parameter so a late joiner doesn't miss earlier output.
+// Registration should wrap this with
+// RequireRole(database.RoleRead, RepositoryIDFromHex, Stream) -- the route
+// has {hex} and {jobID} variables, not {rid}.
+func Stream(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID, err := strconv.ParseInt(vars["jobID"], 10, 64)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job := database.GetJob(jobID)
+
+	offset := 0
+	if o := r.URL.Query().Get("offset"); o != "" {
+		offset, _ = strconv.Atoi(o)
+	}
+
+	if !job.IsRunning() {
+		writeBuffered(w, database.GetCommandLogsForJob(job.ID), offset)
+		return
+	}
+
+	// Subscribe before reading the buffered logs, so a line published in
+	// between is never lost -- and replay only the lines published before
+	// seq, since everything after arrives on lines too and replaying it
+	// again would show it twice.
+	lines, unsubscribe, seq := hub.Subscribe(job.ID)
+	defer unsubscribe()
+
+	logs := database.GetCommandLogsForJob(job.ID)
+
+	if seq < len(logs) {
+		logs = logs[:seq]
+	}
+
+	if !isWebSocketUpgrade(r) {
+		streamSSE(w, r, lines, logs, offset)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+
+	if err != nil {
+		// Upgrade already wrote a failure response.
+		return
+	}
+
+	streamWebSocket(conn, lines, logs, offset)
+}
+
+func writeBuffered(w http.ResponseWriter, logs []database.CommandLog, offset int) {
+	w.Header().Set("Content-Type", "text/plain")
+
+	for i, l := range logs {
+		if i < offset {
+			continue
+		}
+		fmt.Fprintln(w, l.Output)
+	}
+}
+
+func streamWebSocket(conn *websocket.Conn, lines <-chan pubsub.Line, logs []database.CommandLog, offset int) {
+	defer conn.Close()
+
+	for i, l := range logs {
+		if i < offset {
+			continue
+		}
+		if conn.WriteMessage(websocket.TextMessage, []byte(l.Output)) != nil {
+			return
+		}
+	}
+
+	for line := range lines {
+		if conn.WriteMessage(websocket.TextMessage, []byte(line.Text)) != nil {
+			return
+		}
+	}
+}
+
+func streamSSE(w http.ResponseWriter, r *http.Request, lines <-chan pubsub.Line, logs []database.CommandLog, offset int) {
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for i, l := range logs {
+		if i < offset {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", l.Output)
+	}
+	flusher.Flush()
+
+	// Unlike the WebSocket path, an SSE response never errors out of
+	// WriteMessage when the client goes away, so without watching the
+	// request context this loop -- and its subscription -- would never
+	// end.
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", line.Text)
+			flusher.Flush()
+		}
+	}
+}
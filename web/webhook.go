@@ -0,0 +1,42 @@
+package web
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/fallenhitokiri/leeroyci/backlog"
+	"github.com/fallenhitokiri/leeroyci/callbacks"
+	"github.com/fallenhitokiri/leeroyci/database"
+)
+
+// Webhook receives a push webhook for the repository named by the {rid}
+// URL variable, dispatches it to the matching provider's parser, and
+// records which provider sent it so PR commenting and commit statuses can
+// pick the right access token and API base URL. Parsed jobs are queued onto
+// bl rather than handed to the runner directly, so a burst of pushes to the
+// same branch only triggers one build.
+func Webhook(w http.ResponseWriter, r *http.Request, bl *backlog.Backlog) {
+	vars := mux.Vars(r)
+	repositoryID, err := strconv.ParseInt(vars["rid"], 10, 64)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	provider := callbacks.Dispatch(bl, r.Header, body)
+
+	if provider != "" {
+		database.SetRepositoryProvider(repositoryID, string(provider))
+	}
+}